@@ -0,0 +1,91 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file declares the API for checking a Go program and the
+// Config used to control that checking.
+
+package types2
+
+// A Config specifies the configuration for type checking.
+// The zero value for Config is a ready to use default configuration.
+type Config struct {
+	// Context is the context used for resolving global identifiers. If
+	// nil, the type checker will initialize this field with a newly
+	// created context.
+	Context *Context
+
+	// GoVersion describes the accepted Go language version. The string
+	// must follow the format "go%d.%d" (e.g. "go1.12") or it must be
+	// empty; an empty string disables Go language version checks.
+	// If the format is invalid, initializing a Checker with this
+	// Config will cause a panic.
+	GoVersion string
+
+	// If IgnoreFuncBodies is set, function bodies are not type-checked.
+	IgnoreFuncBodies bool
+
+	// If Error is not nil, it is called with each error found
+	// during type checking; err has dynamic type Error.
+	// Secondary errors (for instance, to enumerate all types
+	// involved in an invalid recursive type declaration) have
+	// error strings that start with a '\t' character. If Error
+	// is not set, a Checker calls panic with an Error value.
+	Error func(err error)
+
+	// An importer is used to import packages referred to from
+	// import declarations.
+	// For more dependable type-checking results, each package
+	// should be built and imported only once. If multiple packages
+	// with the same package path are imported by different
+	// importer calls, they may be considered different and
+	// produce mismatching types.
+	Importer Importer
+
+	// If Sizes is not nil, it provides the sizing functions for package
+	// unsafe. Otherwise &StdSizes{WordSize: 8, MaxAlign: 8} is used
+	// instead.
+	Sizes Sizes
+
+	// If DisableUnusedImportCheck is set, packages are not checked
+	// for unused imports.
+	DisableUnusedImportCheck bool
+
+	// Diagnostic, if non-nil, is called in addition to Error for these
+	// assignment errors: a value not assignable to its target, untyped nil
+	// assigned to the blank identifier, and a mismatched number of values
+	// in an assignment or return statement. It gives access to related
+	// spans and suggested fixes that a tool can apply without having to
+	// re-parse the error text. Other errors are reported through Error only.
+	Diagnostic func(Diagnostic)
+
+	// StrictUntyped, if set, turns the implicit default-typing of an
+	// untyped constant assigned to the blank identifier or an interface
+	// (e.g. int, float64, string) into an ImplicitDefaulting error,
+	// requiring an explicit type or conversion instead.
+	StrictUntyped bool
+
+	// Defaulting, if non-nil and StrictUntyped is not set, is called for
+	// every site where an untyped constant is given its default type, so
+	// that a tool can audit or propose fixes for them without turning on
+	// StrictUntyped. It is never called when StrictUntyped is set, since
+	// every such site is already reported as an error.
+	Defaulting func(DefaultingSite)
+
+	// AllowDestructuring, if set, allows a, b := p to destructure a
+	// single right-hand side struct or array value p across multiple
+	// left-hand side variables, when the language version in effect
+	// supports it.
+	AllowDestructuring bool
+
+	// Shadow, if non-nil, is called whenever a short variable
+	// declaration's new variable shadows an existing variable visible
+	// from an enclosing scope, with the new and the shadowed *Var.
+	Shadow func(new, old *Var)
+
+	// ReportShadow, if set, additionally reports each shadowing detected
+	// via Shadow as a soft ShadowedVar error. It defaults to off, since
+	// shadowing is the normal shape of common idioms such as
+	// "if err := f(); err != nil".
+	ReportShadow bool
+}
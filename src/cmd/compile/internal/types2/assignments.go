@@ -42,24 +42,36 @@ func (check *Checker) assignment(x *operand, T Type, context string) {
 		// complex, or string constant."
 		if x.isNil() {
 			if T == nil {
-				check.errorf(x, UntypedNilUse, "use of untyped nil in %s", context)
+				msg := check.diagnosticf(x, UntypedNilUse, nil, nil, "use of untyped nil in %s", context)
+				check.error(x, UntypedNilUse, msg)
 				x.mode = invalid
 				return
 			}
 		} else if T == nil || isNonTypeParamInterface(T) {
 			target = Default(x.typ)
+			if check.recordDefaultingSite(x, target, context) {
+				x.mode = invalid
+				return
+			}
 		}
 		newType, val, code := check.implicitTypeAndValue(x, target)
 		if code != 0 {
-			msg := check.sprintf("cannot use %s as %s value in %s", x, target, context)
+			suffix := ""
 			switch code {
 			case TruncatedFloat:
-				msg += " (truncated)"
+				suffix = " (truncated)"
 			case NumericOverflow:
-				msg += " (overflows)"
+				suffix = " (overflows)"
 			default:
 				code = IncompatibleAssign
 			}
+			var fixes []SuggestedFix
+			if x.isNil() {
+				if fix, ok := zeroLiteralFix(x.expr, target); ok {
+					fixes = append(fixes, fix)
+				}
+			}
+			msg := check.diagnosticf(x, code, nil, fixes, "cannot use %s as %s value in %s%s", x, target, context, suffix)
 			check.error(x, code, msg)
 			x.mode = invalid
 			return
@@ -89,11 +101,14 @@ func (check *Checker) assignment(x *operand, T Type, context string) {
 
 	cause := ""
 	if ok, code := x.assignableTo(check, T, &cause); !ok {
+		fixes := []SuggestedFix{conversionFix(x.expr, T)}
+		var msg string
 		if cause != "" {
-			check.errorf(x, code, "cannot use %s as %s value in %s: %s", x, T, context, cause)
+			msg = check.diagnosticf(x, code, nil, fixes, "cannot use %s as %s value in %s: %s", x, T, context, cause)
 		} else {
-			check.errorf(x, code, "cannot use %s as %s value in %s", x, T, context)
+			msg = check.diagnosticf(x, code, nil, fixes, "cannot use %s as %s value in %s", x, T, context)
 		}
+		check.error(x, code, msg)
 		x.mode = invalid
 	}
 }
@@ -150,7 +165,12 @@ func (check *Checker) initVar(lhs *Var, x *operand, context string) Type {
 				lhs.typ = Typ[Invalid]
 				return nil
 			}
-			typ = Default(typ)
+			def := Default(typ)
+			if check.recordDefaultingSite(x, def, context) {
+				lhs.typ = Typ[Invalid]
+				return nil
+			}
+			typ = def
 		}
 		lhs.typ = typ
 	}
@@ -230,8 +250,12 @@ func (check *Checker) lhsVar(lhs syntax.Expr) Type {
 }
 
 // assignVar checks the assignment lhs = x and returns the type of x.
-// If the assignment is invalid, the result is nil.
-func (check *Checker) assignVar(lhs syntax.Expr, x *operand) Type {
+// If the assignment is invalid, the result is nil. detail, if non-empty,
+// identifies which part of a multi-value rhs x came from (e.g. "field f"
+// when destructuring a struct) and is folded into the reported context
+// so a mismatch names the specific part at fault instead of just the
+// rhs expression as a whole.
+func (check *Checker) assignVar(lhs syntax.Expr, x *operand, detail string) Type {
 	if x.mode == invalid || x.typ == Typ[Invalid] {
 		check.use(lhs)
 		return nil
@@ -246,6 +270,9 @@ func (check *Checker) assignVar(lhs syntax.Expr, x *operand) Type {
 	if T == nil {
 		context = "assignment to _ identifier"
 	}
+	if detail != "" {
+		context += " (" + detail + ")"
+	}
 	check.assignment(x, T, context)
 	if x.mode == invalid {
 		return nil
@@ -313,18 +340,39 @@ func measure(x int, unit string) string {
 	return fmt.Sprintf("%d %s", x, unit)
 }
 
-func (check *Checker) assignError(rhs []syntax.Expr, nvars, nvals int) {
+// assignError reports a "assignment mismatch" error for assigning the nvals
+// values produced by rhs to the nvars variables starting at lhs0 (the first
+// left-hand side identifier or expression).
+func (check *Checker) assignError(lhs0 poser, rhs []syntax.Expr, nvars, nvals int) {
 	vars := measure(nvars, "variable")
 	vals := measure(nvals, "value")
 	rhs0 := rhs[0]
 
+	// If there are more values than variables, the extra values can either
+	// be discarded, or the missing variables can be added as blanks; if
+	// there are more variables than values, there's no value to conjure up
+	// for the extra ones, so no fix is offered.
+	var fixes []SuggestedFix
+	if nvals > nvars {
+		fixes = append(fixes, blankFix(lhs0, nvals-nvars))
+		if len(rhs) > nvars {
+			extra := make([]poser, len(rhs)-nvars)
+			for i, e := range rhs[nvars:] {
+				extra[i] = e
+			}
+			fixes = append(fixes, dropValuesFix(extra))
+		}
+	}
+
 	if len(rhs) == 1 {
 		if call, _ := unparen(rhs0).(*syntax.CallExpr); call != nil {
-			check.errorf(rhs0, WrongAssignCount, "assignment mismatch: %s but %s returns %s", vars, call.Fun, vals)
+			msg := check.diagnosticf(rhs0, WrongAssignCount, nil, fixes, "assignment mismatch: %s but %s returns %s", vars, call.Fun, vals)
+			check.error(rhs0, WrongAssignCount, msg)
 			return
 		}
 	}
-	check.errorf(rhs0, WrongAssignCount, "assignment mismatch: %s but %s", vars, vals)
+	msg := check.diagnosticf(rhs0, WrongAssignCount, nil, fixes, "assignment mismatch: %s but %s", vars, vals)
+	check.error(rhs0, WrongAssignCount, msg)
 }
 
 // If returnStmt != nil, initVars is called to type-check the assignment
@@ -333,6 +381,21 @@ func (check *Checker) initVars(lhs []*Var, orig_rhs []syntax.Expr, returnStmt sy
 	rhs, commaOk := check.exprList(orig_rhs, len(lhs) == 2 && returnStmt == nil)
 
 	if len(lhs) != len(rhs) {
+		// spec extension: allow destructuring a single struct or array
+		// value across multiple variables, e.g. a, b := p where p is a
+		// two-field struct.
+		if returnStmt == nil && len(lhs) > 1 && len(rhs) == 1 && rhs[0].mode != invalid && check.allowDestructuring() {
+			if elems, _, ok := destructurable(check.pkg, rhs[0], len(lhs)); ok {
+				x := *rhs[0]
+				for i, lhs := range lhs {
+					x.typ = elems[i]
+					check.initVar(lhs, &x, "assignment")
+				}
+				check.recordDestructureTypes(orig_rhs[0], elems)
+				return
+			}
+		}
+
 		// invalidate lhs
 		for _, obj := range lhs {
 			obj.used = true // avoid declared and not used errors
@@ -355,15 +418,29 @@ func (check *Checker) initVars(lhs []*Var, orig_rhs []syntax.Expr, returnStmt sy
 			} else if len(rhs) > 0 {
 				at = rhs[len(rhs)-1].expr // report at last value
 			}
+			related := []RelatedInformation{{Pos: posFor(returnStmt), Msg: "return statement"}}
+			var fixes []SuggestedFix
+			if len(rhs) > len(lhs) {
+				extra := make([]poser, len(rhs)-len(lhs))
+				for i, x := range rhs[len(lhs):] {
+					related = append(related, RelatedInformation{Pos: posFor(x.expr), Msg: "unexpected return value"})
+					extra[i] = x.expr
+				}
+				fixes = append(fixes, dropValuesFix(extra))
+			}
+			have := check.typesSummary(operandTypes(rhs), false)
+			want := check.typesSummary(varTypes(lhs), false)
+			check.diagnosticf(at, WrongResultCount, related, fixes, "%s return values\n\thave %s\n\twant %s", qualifier, have, want)
+
 			var err error_
 			err.code = WrongResultCount
 			err.errorf(at, "%s return values", qualifier)
-			err.errorf(nopos, "have %s", check.typesSummary(operandTypes(rhs), false))
-			err.errorf(nopos, "want %s", check.typesSummary(varTypes(lhs), false))
+			err.errorf(nopos, "have %s", have)
+			err.errorf(nopos, "want %s", want)
 			check.report(&err)
 			return
 		}
-		check.assignError(orig_rhs, len(lhs), len(rhs))
+		check.assignError(lhs[0], orig_rhs, len(lhs), len(rhs))
 		return
 	}
 
@@ -400,6 +477,19 @@ func (check *Checker) assignVars(lhs, orig_rhs []syntax.Expr) {
 	rhs, commaOk := check.exprList(orig_rhs, len(lhs) == 2)
 
 	if len(lhs) != len(rhs) {
+		// spec extension: see the matching comment in initVars.
+		if len(lhs) > 1 && len(rhs) == 1 && rhs[0].mode != invalid && check.allowDestructuring() {
+			if elems, labels, ok := destructurable(check.pkg, rhs[0], len(lhs)); ok {
+				x := *rhs[0]
+				for i, l := range lhs {
+					x.typ = elems[i]
+					check.assignVar(l, &x, labels[i])
+				}
+				check.recordDestructureTypes(orig_rhs[0], elems)
+				return
+			}
+		}
+
 		check.use(lhs...)
 		// don't report an error if we already reported one
 		for _, x := range rhs {
@@ -407,14 +497,14 @@ func (check *Checker) assignVars(lhs, orig_rhs []syntax.Expr) {
 				return
 			}
 		}
-		check.assignError(orig_rhs, len(lhs), len(rhs))
+		check.assignError(lhs[0], orig_rhs, len(lhs), len(rhs))
 		return
 	}
 
 	if commaOk {
 		var a [2]Type
 		for i := range a {
-			a[i] = check.assignVar(lhs[i], rhs[i])
+			a[i] = check.assignVar(lhs[i], rhs[i], "")
 		}
 		check.recordCommaOkTypes(orig_rhs[0], a)
 		return
@@ -422,7 +512,7 @@ func (check *Checker) assignVars(lhs, orig_rhs []syntax.Expr) {
 
 	ok := true
 	for i, lhs := range lhs {
-		if check.assignVar(lhs, rhs[i]) == nil {
+		if check.assignVar(lhs, rhs[i], "") == nil {
 			ok = false
 		}
 	}
@@ -507,6 +597,7 @@ func (check *Checker) shortVarDecl(pos syntax.Pos, lhs, rhs []syntax.Expr) {
 		lhsVars[i] = obj
 		if name != "_" {
 			newVars = append(newVars, obj)
+			check.recordShadow(obj, name)
 		}
 		check.recordDef(ident, obj)
 	}
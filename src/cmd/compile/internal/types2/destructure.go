@@ -0,0 +1,79 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements positional destructuring of a single struct or
+// array value across multiple left-hand side variables, e.g.
+//
+//	a, b, c := p // p is a struct with three accessible fields, or [3]T
+//
+// The feature is gated behind Config.AllowDestructuring and a language
+// version check so it cannot silently change the meaning of existing
+// code.
+
+package types2
+
+import (
+	"cmd/compile/internal/syntax"
+	"fmt"
+)
+
+// destructurable reports whether x, a single multi-value right-hand side
+// operand, can be destructured into n positional values from the
+// perspective of package pkg, and if so returns those values' types in
+// order, along with a label for each ("field f" or "element i") for use
+// in error messages that need to single out which one is at fault. It
+// does not consult Config.AllowDestructuring or the language version;
+// callers do that before calling destructurable so the two checks stay
+// in one place.
+func destructurable(pkg *Package, x *operand, n int) (elems []Type, labels []string, ok bool) {
+	switch u := under(x.typ).(type) {
+	case *Struct:
+		if u.NumFields() != n {
+			return nil, nil, false
+		}
+		for i := 0; i < n; i++ {
+			f := u.Field(i)
+			// Same rule as ordinary field selection: a field is usable
+			// if it's exported, or if it's unexported but declared in
+			// the package doing the destructuring.
+			if !f.Exported() && f.Pkg() != pkg {
+				return nil, nil, false
+			}
+			elems = append(elems, f.Type())
+			labels = append(labels, "field "+f.Name())
+		}
+		return elems, labels, true
+	case *Array:
+		if u.Len() != int64(n) {
+			return nil, nil, false
+		}
+		for i := 0; i < n; i++ {
+			elems = append(elems, u.Elem())
+			labels = append(labels, fmt.Sprintf("element %d", i))
+		}
+		return elems, labels, true
+	}
+	return nil, nil, false
+}
+
+// recordDestructureTypes would record the per-element types produced by
+// destructuring rhs into n values, analogous to recordCommaOkTypes but for
+// an arbitrary arity instead of the fixed 2-tuple of a comma-ok expression.
+//
+// Unlike a comma-ok expression, though, rhs here is the single struct or
+// array value being destructured, and check.expr has already recorded its
+// real type (the struct or array type, not any one element's type).
+// Info.Types currently has no way to record more than one type for the
+// same expression, and overwriting rhs's entry with elems[0] would make
+// it lie about rhs's own type. So, until the recorder API grows a
+// multi-value form, this leaves rhs's recorded type alone.
+func (check *Checker) recordDestructureTypes(rhs syntax.Expr, elems []Type) {
+}
+
+// allowDestructuring reports whether destructuring assignment is enabled:
+// the feature flag must be set and the current file must be at least at
+// the language version that introduced it.
+func (check *Checker) allowDestructuring() bool {
+	return check.conf.AllowDestructuring && check.allowVersion(go1_25)
+}
@@ -0,0 +1,35 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types2
+
+import "testing"
+
+func TestDestructurableAllowsUnexportedFieldsInSamePackage(t *testing.T) {
+	pkg := NewPackage("p", "p")
+	s := NewStruct(
+		[]*Var{
+			NewField(nopos, pkg, "a", Typ[Int], false),
+			NewField(nopos, pkg, "b", Typ[String], false),
+		},
+		nil,
+	)
+	x := &operand{mode: value, typ: s}
+
+	elems, labels, ok := destructurable(pkg, x, 2)
+	if !ok {
+		t.Fatalf("destructurable = false, want true for unexported fields in the same package")
+	}
+	if len(elems) != 2 || elems[0] != Typ[Int] || elems[1] != Typ[String] {
+		t.Errorf("elems = %v, want [int string]", elems)
+	}
+	if len(labels) != 2 || labels[0] != "field a" || labels[1] != "field b" {
+		t.Errorf("labels = %v, want [\"field a\" \"field b\"]", labels)
+	}
+
+	other := NewPackage("q", "q")
+	if _, _, ok := destructurable(other, x, 2); ok {
+		t.Errorf("destructurable = true for unexported fields from a different package, want false")
+	}
+}
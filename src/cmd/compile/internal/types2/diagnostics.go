@@ -0,0 +1,153 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements structured, machine-readable diagnostics that
+// accompany the string-based errors produced by the type checker.
+
+package types2
+
+import (
+	"cmd/compile/internal/syntax"
+	. "internal/types/errors"
+	"strings"
+)
+
+// A Diagnostic is a structured, machine-readable description of a type
+// error. It carries the same information as the string error reported
+// through Config.Error, plus related source spans and suggested fixes
+// that a tool can apply without having to re-parse the error text.
+type Diagnostic struct {
+	Code    Code                 // error code, e.g. IncompatibleAssign
+	Pos     syntax.Pos           // primary position of the error
+	Msg     string               // human-readable message, identical to the string error
+	Related []RelatedInformation // related spans, e.g. the return statement and each mismatched value
+	Fixes   []SuggestedFix       // zero or more suggested fixes
+}
+
+// RelatedInformation describes a secondary span relevant to a Diagnostic,
+// such as the enclosing return statement or a mismatched operand.
+type RelatedInformation struct {
+	Pos syntax.Pos
+	Msg string
+}
+
+// A SuggestedFix is a named set of text edits that, applied together,
+// resolve (or help resolve) the associated Diagnostic.
+type SuggestedFix struct {
+	Msg   string
+	Edits []TextEdit
+}
+
+// A TextEdit replaces the source text between Pos and End with New.
+// An empty range (End == Pos) represents an insertion at Pos.
+type TextEdit struct {
+	Pos, End syntax.Pos
+	New      string
+}
+
+// diagnosticf constructs a Diagnostic for the error about to be reported at
+// `at`, forwards it to check.conf.Diagnostic (if installed), and returns the
+// rendered message so the caller can still go through the usual string-based
+// check.errorf/check.error path. This keeps the two reporting mechanisms in
+// sync: the structured Diagnostic and the legacy error string are always
+// built from the same data.
+func (check *Checker) diagnosticf(at poser, code Code, related []RelatedInformation, fixes []SuggestedFix, format string, args ...interface{}) string {
+	msg := check.sprintf(format, args...)
+	if check.conf.Diagnostic != nil {
+		check.conf.Diagnostic(Diagnostic{
+			Code:    code,
+			Pos:     at.Pos(),
+			Msg:     msg,
+			Related: related,
+			Fixes:   fixes,
+		})
+	}
+	return msg
+}
+
+// posFor returns the position of p, independent of whether p is a
+// syntax.Expr, a Var, or any other poser.
+func posFor(p poser) syntax.Pos {
+	return p.Pos()
+}
+
+// conversionFix suggests wrapping x in an explicit conversion to target,
+// e.g. turning `var s string = i` into `var s string = string(i)`.
+func conversionFix(x syntax.Expr, target Type) SuggestedFix {
+	pos, end := syntax.StartPos(x), syntax.EndPos(x)
+	return SuggestedFix{
+		Msg: "add explicit conversion",
+		Edits: []TextEdit{
+			{Pos: pos, End: pos, New: TypeString(target, nil) + "("},
+			{Pos: end, End: end, New: ")"},
+		},
+	}
+}
+
+// zeroLiteralFix suggests replacing the nil expression x with the zero
+// literal for typ, e.g. turning `var n int = nil` into `var n int = 0`.
+func zeroLiteralFix(x syntax.Expr, typ Type) (SuggestedFix, bool) {
+	lit, ok := zeroLiteralFor(typ)
+	if !ok {
+		return SuggestedFix{}, false
+	}
+	pos, end := syntax.StartPos(x), syntax.EndPos(x)
+	return SuggestedFix{
+		Msg:   "use the zero value for " + TypeString(typ, nil),
+		Edits: []TextEdit{{Pos: pos, End: end, New: lit}},
+	}, true
+}
+
+// zeroLiteralFor returns a textual zero literal for typ's underlying type,
+// if one can be expressed without additional imports.
+func zeroLiteralFor(typ Type) (string, bool) {
+	switch u := under(typ).(type) {
+	case *Basic:
+		switch {
+		case u.Info()&IsBoolean != 0:
+			return "false", true
+		case u.Info()&IsString != 0:
+			return `""`, true
+		case u.Info()&IsNumeric != 0:
+			return "0", true
+		}
+	case *Pointer, *Slice, *Map, *Chan, *Signature, *Interface:
+		return "nil", true
+	}
+	return "", false
+}
+
+// blankFix suggests adding a "_" for each of the n missing left-hand side
+// variables, inserted immediately before at.
+func blankFix(at poser, n int) SuggestedFix {
+	pos := posFor(at)
+	if e, _ := at.(syntax.Expr); e != nil {
+		pos = syntax.StartPos(e)
+	}
+	ins := strings.Repeat("_, ", n)
+	return SuggestedFix{
+		Msg:   "add missing blank identifiers",
+		Edits: []TextEdit{{Pos: pos, End: pos, New: ins}},
+	}
+}
+
+// dropValuesFix suggests removing all of the extra values in extra (extra
+// return values, or extra right-hand side values of an assignment), as a
+// single fix: dropping only some of several extra values would still
+// leave the arity mismatched, so all of them must be removed together.
+func dropValuesFix(extra []poser) SuggestedFix {
+	edits := make([]TextEdit, len(extra))
+	for i, at := range extra {
+		pos, end := posFor(at), posFor(at)
+		if e, _ := at.(syntax.Expr); e != nil {
+			pos, end = syntax.StartPos(e), syntax.EndPos(e)
+		}
+		edits[i] = TextEdit{Pos: pos, End: end, New: ""}
+	}
+	msg := "drop extra value"
+	if len(extra) > 1 {
+		msg = "drop extra values"
+	}
+	return SuggestedFix{Msg: msg, Edits: edits}
+}
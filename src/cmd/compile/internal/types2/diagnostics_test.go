@@ -0,0 +1,27 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types2
+
+import (
+	"cmd/compile/internal/syntax"
+	"testing"
+)
+
+func TestBlankFixInsertsBeforeLHS(t *testing.T) {
+	lhs := &syntax.Name{Value: "x"}
+	lhs.SetPos(syntax.MakePos(nil, 1, 1))
+
+	fix := blankFix(lhs, 2)
+	if got, want := len(fix.Edits), 1; got != want {
+		t.Fatalf("len(fix.Edits) = %d, want %d", got, want)
+	}
+	edit := fix.Edits[0]
+	if edit.Pos != lhs.Pos() || edit.End != lhs.Pos() {
+		t.Errorf("edit anchored at %v, want insertion at lhs position %v", edit.Pos, lhs.Pos())
+	}
+	if want := "_, _, "; edit.New != want {
+		t.Errorf("edit.New = %q, want %q", edit.New, want)
+	}
+}
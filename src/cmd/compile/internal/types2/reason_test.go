@@ -0,0 +1,68 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types2
+
+import (
+	. "internal/types/errors"
+	"testing"
+)
+
+func TestClassifyCausePrefersCodeForOverflow(t *testing.T) {
+	// Even though the cause string talks about methods, a NumericOverflow
+	// or TruncatedFloat code must win: it is the authoritative signal.
+	r := classifyCause("some unrelated method text", NumericOverflow, nil, nil)
+	if r.Code != UntypedOverflow {
+		t.Errorf("Code = %v, want UntypedOverflow", r.Code)
+	}
+}
+
+func TestClassifyCauseDetectsMissingMethodStructurally(t *testing.T) {
+	pkg := NewPackage("p", "p")
+	sig := NewSignatureType(nil, nil, nil, nil, nil, false)
+	iface := NewInterfaceType([]*Func{NewFunc(nopos, pkg, "Foo", sig)}, nil)
+	iface.Complete()
+
+	s := NewStruct(nil, nil)
+	r := classifyCause("", IncompatibleAssign, s, iface)
+	if r.Code != MissingMethod {
+		t.Errorf("Code = %v, want MissingMethod", r.Code)
+	}
+	if r.Method != "Foo" {
+		t.Errorf("Method = %q, want Foo", r.Method)
+	}
+}
+
+func TestClassifyCauseDetectsWrongMethodSignatureStructurally(t *testing.T) {
+	pkg := NewPackage("p", "p")
+	wantSig := NewSignatureType(nil, nil, nil, nil, NewTuple(NewVar(nopos, pkg, "", Typ[Int])), false)
+	haveSig := NewSignatureType(nil, nil, nil, nil, nil, false)
+	iface := NewInterfaceType([]*Func{NewFunc(nopos, pkg, "Foo", wantSig)}, nil)
+	iface.Complete()
+
+	have := NewInterfaceType([]*Func{NewFunc(nopos, pkg, "Foo", haveSig)}, nil)
+	have.Complete()
+
+	r := classifyCause("", IncompatibleAssign, have, iface)
+	if r.Code != WrongMethodSignature {
+		t.Errorf("Code = %v, want WrongMethodSignature", r.Code)
+	}
+	if r.Method != "Foo" {
+		t.Errorf("Method = %q, want Foo", r.Method)
+	}
+}
+
+func TestClassifyCauseDetectsChannelDirMismatchStructurally(t *testing.T) {
+	r := classifyCause("", IncompatibleAssign, NewChan(RecvOnly, Typ[Int]), NewChan(SendOnly, Typ[Int]))
+	if r.Code != BidirectionalChannelMismatch {
+		t.Errorf("Code = %v, want BidirectionalChannelMismatch", r.Code)
+	}
+}
+
+func TestClassifyCauseFallsBackToCauseForConstraints(t *testing.T) {
+	r := classifyCause("T does not satisfy comparable", IncompatibleAssign, Typ[Int], Typ[String])
+	if r.Code != TypeParamConstraintUnsatisfied {
+		t.Errorf("Code = %v, want TypeParamConstraintUnsatisfied", r.Code)
+	}
+}
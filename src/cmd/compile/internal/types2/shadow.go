@@ -0,0 +1,49 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements shadow-variable detection for short variable
+// declarations. It folds the core of golang.org/x/tools/go/analysis/
+// passes/shadow into the type checker itself, so every consumer of
+// types2 gets it for free and it stays correct for generics and for
+// := in if/for/switch init clauses.
+
+package types2
+
+import (
+	"fmt"
+	. "internal/types/errors"
+)
+
+// recordShadow reports, via Config.Shadow and, if Config.ReportShadow is
+// set, as a soft error, that the newly declared variable obj shadows an
+// existing variable named name visible from an enclosing scope. It is a
+// no-op if no such variable exists. It does not itself exclude a name
+// already declared in the current scope; shortVarDecl handles that case
+// as a redeclaration and only calls recordShadow for new variables.
+//
+// ReportShadow defaults to off: shadowing is the normal shape of the
+// extremely common "if err := f(); err != nil" idiom, so reporting it
+// unconditionally would turn routine code into a hard build error.
+// Config.Shadow is still called whenever set, so a caller such as gopls
+// can collect shadow events for its own diagnostics without opting into
+// ReportShadow.
+func (check *Checker) recordShadow(obj *Var, name string) {
+	old, _ := check.lookup(name).(*Var)
+	if old == nil {
+		return
+	}
+	if check.conf.Shadow != nil {
+		check.conf.Shadow(obj, old)
+	}
+	if check.conf.ReportShadow {
+		check.softErrorf(obj, ShadowedVar, "%s", shadowMessage(name, old))
+	}
+}
+
+// shadowMessage is the message reported when a new declaration of name
+// shadows the existing declaration old, factored out of recordShadow so
+// it can be tested without a *Checker.
+func shadowMessage(name string, old *Var) string {
+	return fmt.Sprintf("declaration of %s shadows declaration at %s", name, old.Pos())
+}
@@ -0,0 +1,20 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShadowMessageNamesBothDeclarations(t *testing.T) {
+	pkg := NewPackage("p", "p")
+	old := NewVar(nopos, pkg, "err", Typ[Int])
+
+	got := shadowMessage("err", old)
+	if !strings.Contains(got, "declaration of err shadows declaration at") {
+		t.Errorf("shadowMessage(%q, old) = %q, missing expected wording", "err", got)
+	}
+}
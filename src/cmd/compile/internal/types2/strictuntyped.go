@@ -0,0 +1,61 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements Config.StrictUntyped, an opt-in mode that turns
+// implicit default-type conversion of untyped constants into an error,
+// so that large codebases can audit and eliminate it.
+
+package types2
+
+import (
+	"cmd/compile/internal/syntax"
+	. "internal/types/errors"
+)
+
+// A DefaultingSite records a place where an untyped constant would be
+// (or, outside StrictUntyped mode, was) given its default type rather
+// than an explicit one.
+type DefaultingSite struct {
+	Pos     syntax.Pos
+	Default Type // the default type that would be used, e.g. Typ[Int]
+}
+
+// recordDefaultingSite is called at each point where an untyped constant
+// would be given its default type. If Config.StrictUntyped is set, it
+// reports an ImplicitDefaulting error instead of letting the caller
+// silently default x, and reports whether the caller should treat x as
+// invalid and stop. Otherwise, if Config.Defaulting is set, it is called
+// with the site so a tool can collect them (e.g. to propose inserting
+// explicit types); with neither option set this call does no work beyond
+// the two nil/bool checks, so it adds no overhead to ordinary compiles.
+//
+// This is a deliberate departure from a Checker-scoped []DefaultingSite:
+// a Checker lives for the duration of a whole package's type-checking, so
+// an always-on slice would hold every defaulting site in memory for every
+// compile, not just the StrictUntyped-audit ones that want it. A callback
+// lets a caller that wants the list build it itself (append in the
+// callback), while ordinary compiles that never set Config.Defaulting pay
+// nothing for it.
+func (check *Checker) recordDefaultingSite(x *operand, def Type, context string) (reject bool) {
+	if !isDefaultingSite(x) {
+		return false
+	}
+	if check.conf.StrictUntyped {
+		check.errorf(x, ImplicitDefaulting, "cannot use %s as %s value in %s: requires explicit type (StrictUntyped mode)", x, def, context)
+		return true
+	}
+	if check.conf.Defaulting != nil {
+		check.conf.Defaulting(DefaultingSite{Pos: x.Pos(), Default: def})
+	}
+	return false
+}
+
+// isDefaultingSite reports whether x is the kind of untyped value that
+// StrictUntyped and Config.Defaulting apply to: an untyped *constant*,
+// such as 42 or "s". It excludes untyped non-constant values, such as the
+// untyped bool produced by a == b, which still default in the usual way
+// but were never in scope for this feature.
+func isDefaultingSite(x *operand) bool {
+	return x.mode == constant_
+}
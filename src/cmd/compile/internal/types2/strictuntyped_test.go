@@ -0,0 +1,24 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types2
+
+import "testing"
+
+func TestIsDefaultingSiteAcceptsUntypedConstant(t *testing.T) {
+	x := &operand{mode: constant_, typ: Typ[UntypedInt]}
+	if !isDefaultingSite(x) {
+		t.Errorf("isDefaultingSite = false, want true for an untyped constant")
+	}
+}
+
+func TestIsDefaultingSiteRejectsUntypedNonConstant(t *testing.T) {
+	// The untyped bool produced by a comparison like a == b: untyped, but
+	// not a constant, so StrictUntyped must not treat it as a defaulting
+	// site.
+	x := &operand{mode: value, typ: Typ[UntypedBool]}
+	if isDefaultingSite(x) {
+		t.Errorf("isDefaultingSite = true, want false for an untyped non-constant value")
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements a structured counterpart to the "cause" strings
+// produced while checking assignability, so that callers other than the
+// error printer can act on *why* an assignment failed.
+//
+// This is the go/types twin of cmd/compile/internal/types2/reason.go;
+// keep them in sync.
+
+package types
+
+import (
+	. "internal/types/errors"
+	"strings"
+)
+
+// A ReasonCode classifies why V is not assignable to T.
+type ReasonCode int
+
+const (
+	// NoReason indicates that there is no further explanation beyond
+	// the assignability failure itself.
+	NoReason ReasonCode = iota
+	// MissingMethod indicates that T is an interface and V is missing
+	// one of its methods.
+	MissingMethod
+	// WrongMethodSignature indicates that T is an interface and V has
+	// a method of the right name but the wrong signature.
+	WrongMethodSignature
+	// TypeParamConstraintUnsatisfied indicates that V does not satisfy
+	// a type parameter's constraint. classifyCause can produce this code
+	// given a cause string that says so, but AssignableToReason itself
+	// never has one to give it (see its doc comment), so this code is
+	// never actually returned through the public API today.
+	TypeParamConstraintUnsatisfied
+	// UntypedOverflow indicates that an untyped constant does not fit
+	// in T's range.
+	UntypedOverflow
+	// BidirectionalChannelMismatch indicates that V and T are channel
+	// types that differ in direction or require a bidirectional channel.
+	BidirectionalChannelMismatch
+)
+
+// A Reason is a structured explanation of why V is not assignable to T,
+// mirroring the cause string built internally by assignableTo.
+type Reason struct {
+	Code   ReasonCode
+	V, T   Type
+	Method string // offending method or field name, if any
+}
+
+// AssignableToReason reports whether a value of type V is assignable to a
+// variable of type T and, if not, a structured Reason explaining why. It
+// is the structured counterpart of AssignableTo, for callers (such as
+// IDEs and refactoring tools) that want to act on the failure rather than
+// just print it.
+//
+// Like AssignableTo itself ("check not needed for non-constant x"),
+// AssignableToReason has no *Checker to hand to assignableTo, so it
+// passes nil for both check and cause: the same nil/nil shape
+// AssignableTo has always used, and has never panicked through the
+// interface/missing-method path, since that path only dereferences
+// check when asked to build a cause. See the identical comment in the
+// types2 twin of classifyCause for what this costs.
+func AssignableToReason(V, T Type) (bool, Reason) {
+	x := operand{mode: value, typ: V}
+	ok, code := x.assignableTo(nil, T, nil)
+	if ok {
+		return true, Reason{}
+	}
+	return false, classifyCause("", code, V, T)
+}
+
+// classifyCause maps the error code produced by assignableTo, together
+// with V and T themselves, onto a structured Reason. See the identical
+// comment in the types2 twin of this function for why a cause-string
+// fallback is still needed for the type parameter constraint case, why
+// AssignableToReason can never supply one, and why
+// TypeParamConstraintUnsatisfied is consequently unreachable through
+// AssignableToReason today even though classifyCause can still produce
+// it.
+func classifyCause(cause string, code Code, V, T Type) Reason {
+	r := Reason{Code: NoReason, V: V, T: T}
+	switch code {
+	case NumericOverflow, TruncatedFloat:
+		r.Code = UntypedOverflow
+		return r
+	}
+	if iface, _ := under(T).(*Interface); iface != nil {
+		if m, wrongType := MissingMethod(V, iface, true); m != nil {
+			if wrongType {
+				r.Code = WrongMethodSignature
+			} else {
+				r.Code = MissingMethod
+			}
+			r.Method = m.Name()
+			return r
+		}
+	}
+	if Vc, _ := under(V).(*Chan); Vc != nil {
+		if Tc, _ := under(T).(*Chan); Tc != nil && Vc.Dir() != Tc.Dir() {
+			r.Code = BidirectionalChannelMismatch
+			return r
+		}
+	}
+	switch {
+	case containsAny(cause, "does not satisfy"):
+		r.Code = TypeParamConstraintUnsatisfied
+	}
+	return r
+}
+
+func containsAny(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errors
+
+// A Code is a (package, source location)-independent code that
+// identifies a type checking error.
+type Code int
+
+const (
+	_ Code = iota
+
+	// BadDecl occurs when a declaration has invalid syntax that the
+	// parser failed to catch.
+	BadDecl
+
+	// IncompatibleAssign occurs when the type of the right-hand side
+	// of an assignment or conversion is not assignable to the type of
+	// the left-hand side.
+	//
+	// Example:
+	//  var x []int
+	//  var y string
+	//  func main() {
+	//  	x = y
+	//  }
+	IncompatibleAssign
+
+	// InvalidConstInit occurs when a const declaration has a
+	// non-constant initializer.
+	//
+	// Example:
+	//  var x int
+	//  const y = x
+	InvalidConstInit
+
+	// NoNewVar occurs when a short variable declaration (':=') does not
+	// declare new variables.
+	//
+	// Example:
+	//  func _() {
+	//  	x := 1
+	//  	x := 2
+	//  }
+	NoNewVar
+
+	// NumericOverflow occurs when a numeric constant overflows its
+	// target type.
+	//
+	// Example:
+	//  const x int8 = 1000
+	NumericOverflow
+
+	// RepeatedDecl occurs when an identifier is declared more than
+	// once on the left-hand side of a short variable declaration.
+	//
+	// Example:
+	//  func _() {
+	//  	x, y, y := 1, 2, 3
+	//  	_, _, _ = x, y, y
+	//  }
+	RepeatedDecl
+
+	// TruncatedFloat occurs when a floating-point constant is
+	// truncated to an integer value.
+	//
+	// Example:
+	//  const x int = 1.1
+	TruncatedFloat
+
+	// UnaddressableFieldAssign occurs when trying to assign to a
+	// struct field in a map value.
+	//
+	// Example:
+	//  func f() {
+	//  	m := make(map[string]struct{ X int })
+	//  	m["x"].X = 1
+	//  }
+	UnaddressableFieldAssign
+
+	// UnassignableOperand occurs when the left-hand side of an
+	// assignment is not addressable or is not a map index expression.
+	//
+	// Example:
+	//  func f() {
+	//  	1 = 2
+	//  }
+	UnassignableOperand
+
+	// UntypedNilUse occurs when the predeclared (untyped) value nil is
+	// used to initialize a variable declared without an explicit
+	// type.
+	//
+	// Example:
+	//  var x = nil
+	UntypedNilUse
+
+	// WrongAssignCount occurs when the number of values on the
+	// right-hand side of an assignment or initialization expression
+	// does not match the number of variables on the left-hand side.
+	//
+	// Example:
+	//  func f() (int, int) {
+	//  	return 1, 2
+	//  }
+	//
+	//  func _() {
+	//  	a, b, c := f()
+	//  }
+	WrongAssignCount
+
+	// WrongResultCount occurs when a return statement returns the
+	// wrong number of values.
+	//
+	// Example:
+	//  func f() (int, int) {
+	//  	return 1
+	//  }
+	WrongResultCount
+
+	// WrongTypeArgCount occurs when a generic function or type is
+	// instantiated with the wrong number of type arguments, including
+	// when a generic function is used without instantiation in a
+	// context requiring a non-generic value.
+	//
+	// Example:
+	//  func f[T any]() {}
+	//
+	//  var _ = f
+	WrongTypeArgCount
+
+	// ImplicitDefaulting occurs when Config.StrictUntyped is set and an
+	// untyped constant would otherwise be given its default type (e.g.
+	// int, float64, string) rather than an explicit one.
+	//
+	// Example:
+	//  // package p, with Config.StrictUntyped set
+	//  var _ interface{} = 42
+	ImplicitDefaulting
+
+	// ShadowedVar occurs when Config.ReportShadow is set and a short
+	// variable declaration's new variable shadows an existing variable
+	// visible from an enclosing scope.
+	//
+	// Example:
+	//  // package p, with Config.ReportShadow set
+	//  func f(err error) {
+	//  	if err := g(); err != nil {
+	//  	}
+	//  }
+	ShadowedVar
+)